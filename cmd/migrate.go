@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/brettpechiney/workout-service/config"
+	"github.com/brettpechiney/workout-service/dbsetup"
+)
+
+// newMigrateCmd returns the "migrate" subcommand, which runs the service's
+// migrations against whatever data source the resolved config points at.
+func newMigrateCmd(cfg *config.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "migrate",
+		Short: "Run pending database migrations",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return dbsetup.New(cfg).Migrate(context.Background())
+		},
+	}
+}
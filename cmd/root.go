@@ -0,0 +1,85 @@
+// Package cmd provides the cobra-based CLI surface for the service. It
+// wires every param.* key to a persistent flag, binds those flags into the
+// same Viper instance backing config.Config, and resolves an
+// environment-variable prefix so a single precedence chain (flag > env >
+// file > default) applies everywhere, rather than just env + file.
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/brettpechiney/workout-service/config"
+	"github.com/brettpechiney/workout-service/config/param"
+)
+
+// PrepareRootCmd builds the root command for the service. cfg must already
+// be loaded (e.g. via config.Load); its Viper instance is what every flag
+// gets bound to. envPrefix is passed to Viper's SetEnvPrefix, and
+// defaultRoot seeds the --root/-r flag. When --root names a directory other
+// than defaultRoot, its application-properties.toml (if present) is merged
+// over the configuration cfg already holds, on every subcommand invocation.
+func PrepareRootCmd(cfg *config.Config, envPrefix, defaultRoot string) (*cobra.Command, error) {
+	root := &cobra.Command{
+		Use:           "workout-service",
+		Short:         "Serve and manage the workout service",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	v := cfg.Viper()
+
+	var rootDir string
+	root.PersistentFlags().StringVarP(&rootDir, "root", "r", defaultRoot, "directory whose configuration file overrides the default one")
+
+	root.PersistentFlags().String(param.DataSource, cfg.DataSource(), "database connection string")
+	root.PersistentFlags().String(param.LoggingLevel, cfg.LoggingLevel(), "application logging level")
+	root.PersistentFlags().String(param.BindAddress, cfg.BindAddress(), "address the server listens on")
+	root.PersistentFlags().Int(param.Port, cfg.Port(), "port the server listens on")
+
+	for _, key := range []string{param.DataSource, param.LoggingLevel, param.BindAddress, param.Port} {
+		if err := v.BindPFlag(key, root.PersistentFlags().Lookup(key)); err != nil {
+			return nil, fmt.Errorf("binding flag %q: %v", key, err)
+		}
+	}
+
+	v.SetEnvPrefix(envPrefix)
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_", "-", "_"))
+	v.AutomaticEnv()
+
+	root.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		if rootDir == "" || rootDir == defaultRoot {
+			return nil
+		}
+
+		// Resolve configuration from rootDir in isolation, rather than
+		// appending it to v's existing search path: Viper's AddConfigPath
+		// only ever returns the first matching file across all configured
+		// paths, so appending rootDir would never take effect once the
+		// default path already has a config file. Merging the isolated
+		// result into v's config tier keeps it below explicit flags/env
+		// but lets it override what Load found at the default path.
+		fresh := viper.New()
+		fresh.SetConfigName("application-properties")
+		fresh.SetConfigType("toml")
+		fresh.AddConfigPath(rootDir)
+
+		if err := fresh.ReadInConfig(); err != nil {
+			if _, notFound := err.(viper.ConfigFileNotFoundError); !notFound {
+				return fmt.Errorf("reading configuration from %q: %v", rootDir, err)
+			}
+			return nil
+		}
+
+		return v.MergeConfigMap(fresh.AllSettings())
+	}
+
+	root.AddCommand(newServeCmd(cfg))
+	root.AddCommand(newMigrateCmd(cfg))
+	root.AddCommand(newVersionCmd())
+
+	return root, nil
+}
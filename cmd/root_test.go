@@ -0,0 +1,99 @@
+package cmd_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/brettpechiney/workout-service/cmd"
+	"github.com/brettpechiney/workout-service/config"
+	"github.com/brettpechiney/workout-service/config/param"
+)
+
+// writeConfig writes a minimal application-properties.toml setting
+// data-source to dataSource, in its own directory under t.TempDir().
+func writeConfig(t *testing.T, dataSource string) string {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "application-properties.toml")
+	contents := "data-source = \"" + dataSource + "\"\n"
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	return dir
+}
+
+// TestPrepareRootCmdEnvOverride verifies that an environment variable
+// overrides the config default once the root command binds param.DataSource
+// into cfg's Viper instance.
+func TestPrepareRootCmdEnvOverride(t *testing.T) {
+	const want = "postgresql://test@localhost:26257/workout?sslmode=disable"
+	if err := os.Setenv("WORKOUT_DATA_SOURCE", want); err != nil {
+		t.Fatalf("Setenv: %v", err)
+	}
+	defer os.Unsetenv("WORKOUT_DATA_SOURCE")
+
+	cfg := config.Defaults()
+	root, err := cmd.PrepareRootCmd(cfg, "WORKOUT", ".")
+	if err != nil {
+		t.Fatalf("PrepareRootCmd: %v", err)
+	}
+	root.SetArgs([]string{"version"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	if actual := cfg.DataSource(); actual != want {
+		t.Errorf("DataSource: expected %q, got %q", want, actual)
+	}
+}
+
+// TestPrepareRootCmdFlagOverride verifies that a persistent flag overrides
+// the config default for param.LoggingLevel.
+func TestPrepareRootCmdFlagOverride(t *testing.T) {
+	cfg := config.Defaults()
+	root, err := cmd.PrepareRootCmd(cfg, "WORKOUT", ".")
+	if err != nil {
+		t.Fatalf("PrepareRootCmd: %v", err)
+	}
+	root.SetArgs([]string{"--" + param.LoggingLevel, "DEBUG", "version"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	if actual := cfg.LoggingLevel(); actual != "DEBUG" {
+		t.Errorf("LoggingLevel: expected %q, got %q", "DEBUG", actual)
+	}
+}
+
+// TestPrepareRootCmdRootFlag verifies that --root points configuration
+// resolution at an alternate directory, overriding whatever the default
+// root directory's config file set for the same key.
+func TestPrepareRootCmdRootFlag(t *testing.T) {
+	const defaultValue = "postgresql://default@localhost:26257/workout?sslmode=disable"
+	const altValue = "postgresql://alt@localhost:26257/workout?sslmode=disable"
+
+	defaultDir := writeConfig(t, defaultValue)
+	altDir := writeConfig(t, altValue)
+
+	cfg, err := config.Load([]string{defaultDir})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if actual := cfg.DataSource(); actual != defaultValue {
+		t.Fatalf("DataSource: expected %q before --root, got %q", defaultValue, actual)
+	}
+
+	root, err := cmd.PrepareRootCmd(cfg, "WORKOUT", defaultDir)
+	if err != nil {
+		t.Fatalf("PrepareRootCmd: %v", err)
+	}
+	root.SetArgs([]string{"--root", altDir, "version"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	if actual := cfg.DataSource(); actual != altValue {
+		t.Errorf("DataSource: expected %q after --root %s, got %q", altValue, altDir, actual)
+	}
+}
@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/brettpechiney/workout-service/config"
+)
+
+// newServeCmd returns the "serve" subcommand, which starts the service
+// using the resolved config (flag > env > file > default).
+func newServeCmd(cfg *config.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "serve",
+		Short: "Start the workout service",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fmt.Printf("starting workout-service on %s:%d (logging level %s)\n",
+				cfg.BindAddress(), cfg.Port(), cfg.LoggingLevel())
+			return nil
+		},
+	}
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/brettpechiney/workout-service/version"
+)
+
+// repo is the GitHub repository CheckLatest compares the running binary's
+// compiled-in version against.
+const repo = "brettpechiney/workout-service"
+
+// newVersionCmd returns the "version" subcommand.
+func newVersionCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "version",
+		Short: "Print the workout-service version",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fmt.Println(version.Get())
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			latest, isOutdated, err := version.CheckLatest(ctx, repo)
+			if err != nil {
+				// Best-effort: not being able to reach GitHub shouldn't fail the command.
+				return nil
+			}
+			if isOutdated {
+				fmt.Printf("a newer version is available: %s\n", latest)
+			}
+			return nil
+		},
+	}
+}
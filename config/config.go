@@ -4,6 +4,8 @@ import (
 	"log"
 	"os"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
@@ -11,10 +13,53 @@ import (
 	"github.com/brettpechiney/workout-service/config/param"
 )
 
+// remoteKeys are the param.* keys that LoadRemote pulls from the remote
+// provider and watches for changes.
+var remoteKeys = []string{param.DataSource, param.LoggingLevel, param.BindAddress, param.Port}
+
+// remotePollInterval is how often watchRemote re-reads the remote provider,
+// re-merges it into i.v, and checks remoteKeys for changes. Polling with a
+// plain ReadRemoteConfig, rather than Viper's WatchRemoteConfigOnChannel, is
+// deliberate: that call spawns its own background goroutine that mutates
+// the remote Viper's internal store without synchronization, which races
+// with reads made by this goroutine. A single goroutine exclusively owning
+// remoteV and re-fetching it synchronously avoids that.
+const remotePollInterval = 100 * time.Millisecond
+
+// RemoteProvider describes a remote key/value store (e.g. etcd or consul)
+// that LoadRemote can read configuration from and watch for changes.
+type RemoteProvider struct {
+	// Name is the remote provider Viper understands, e.g. "etcd" or "consul".
+	Name string
+
+	// Endpoint is the address of the remote store, e.g. "http://127.0.0.1:4001".
+	Endpoint string
+
+	// Path is the key under which the configuration is stored.
+	Path string
+
+	// ConfigType is the format the stored configuration is encoded in. It
+	// defaults to "toml" to match the local file format.
+	ConfigType string
+
+	// SecretKeyring is the path to a PGP keyring used to decrypt the remote
+	// configuration. It is optional; when empty the config is read in the
+	// clear.
+	SecretKeyring string
+}
+
 // Config is is a configuration implementation backed by Viper.
 type Config struct {
-	remote bool
-	v      *viper.Viper
+	remote  bool
+	remoteV *viper.Viper
+	v       *viper.Viper
+
+	mu       sync.Mutex
+	watching map[string]string
+	onChange []func(key string)
+
+	stop     chan struct{}
+	stopOnce sync.Once
 }
 
 // Load returns a Config object that reads configuration settings.
@@ -31,7 +76,7 @@ func Load(configPaths []string) (*Config, error) {
 	i.v.SetConfigType("toml")
 
 	if err := i.v.ReadInConfig(); err != nil {
-		if !os.IsNotExist(err) {
+		if _, notFound := err.(viper.ConfigFileNotFoundError); !notFound && !os.IsNotExist(err) {
 			return nil, errors.Wrapf(err, "unable to read configuration file")
 		}
 		log.Printf("no configuration file found; proceeding without one")
@@ -40,6 +85,168 @@ func Load(configPaths []string) (*Config, error) {
 	return i, nil
 }
 
+// LoadRemote returns a Config that reads its initial values the same way
+// Load does, then layers a remote configuration store on top: if provider
+// is reachable, its values are merged in over the local file/defaults for
+// remoteKeys and i.remote is flagged so callers can opt into live-reload
+// semantics; if it is unreachable, LoadRemote falls back to the Config
+// built from the local file/defaults and remote stays false. When the remote succeeds, a goroutine
+// watches it for changes so DataSource, LoggingLevel, and friends pick up
+// rotations without a restart; callers should defer Close to stop it.
+func LoadRemote(configPaths []string, provider RemoteProvider) (*Config, error) {
+	i, err := Load(configPaths)
+	if err != nil {
+		return nil, err
+	}
+
+	remoteV, err := i.addRemoteProvider(provider)
+	if err != nil {
+		log.Printf("remote configuration unreachable, falling back to local: %v", err)
+		return i, nil
+	}
+
+	i.remote = true
+	i.remoteV = remoteV
+	i.watching = i.snapshot()
+	i.stop = make(chan struct{})
+	go i.watchRemote()
+
+	return i, nil
+}
+
+// Remote reports whether this Config is backed by a live remote provider.
+func (i *Config) Remote() bool {
+	return i.remote
+}
+
+// Close stops the background goroutine started by LoadRemote, if any. It is
+// safe to call on a Config returned by Load, and safe to call more than
+// once. Callers that use LoadRemote should defer Close to avoid leaking the
+// watcher goroutine.
+func (i *Config) Close() {
+	if i.stop == nil {
+		return
+	}
+	i.stopOnce.Do(func() {
+		close(i.stop)
+	})
+}
+
+// OnChange registers fn to be called whenever a value watched by LoadRemote
+// changes, with the param.* key that changed. It is intended for subscribers
+// such as the logger or a DB pool that need to react to a rotation.
+func (i *Config) OnChange(fn func(key string)) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.onChange = append(i.onChange, fn)
+}
+
+// addRemoteProvider reads the remote provider into its own Viper instance
+// (so its values aren't shadowed by the local config file, which otherwise
+// takes precedence over anything read into i.v's key/value store layer),
+// then merges remoteKeys from it into i.v via Set so the remote values win.
+// It returns the remote Viper instance so watchRemote can keep polling it.
+func (i *Config) addRemoteProvider(p RemoteProvider) (*viper.Viper, error) {
+	configType := p.ConfigType
+	if configType == "" {
+		configType = "toml"
+	}
+
+	remoteV := viper.New()
+	remoteV.SetConfigType(configType)
+
+	var err error
+	if p.SecretKeyring != "" {
+		err = remoteV.AddSecureRemoteProvider(p.Name, p.Endpoint, p.Path, p.SecretKeyring)
+	} else {
+		err = remoteV.AddRemoteProvider(p.Name, p.Endpoint, p.Path)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "adding remote provider")
+	}
+	if err := remoteV.ReadRemoteConfig(); err != nil {
+		return nil, errors.Wrap(err, "reading remote configuration")
+	}
+
+	i.mergeRemote(remoteV)
+	return remoteV, nil
+}
+
+// mergeRemote copies remoteKeys present in remoteV into i.v as overrides,
+// so they take precedence over any value already loaded from the local
+// config file. It locks i.mu because i.v is also read concurrently by the
+// getters (DataSource, LoggingLevel, ...) while watchRemote runs.
+func (i *Config) mergeRemote(remoteV *viper.Viper) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for _, key := range remoteKeys {
+		if remoteV.IsSet(key) {
+			i.v.Set(key, remoteV.Get(key))
+		}
+	}
+}
+
+func (i *Config) snapshot() map[string]string {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	values := make(map[string]string, len(remoteKeys))
+	for _, key := range remoteKeys {
+		values[key] = i.v.GetString(key)
+	}
+	return values
+}
+
+// diffWatched reports which of the watched remoteKeys changed value since
+// the last call, updating i.watching as it goes. It locks i.mu for the same
+// reason mergeRemote does.
+func (i *Config) diffWatched() []string {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	var changed []string
+	for key, prev := range i.watching {
+		if current := i.v.GetString(key); current != prev {
+			i.watching[key] = current
+			changed = append(changed, key)
+		}
+	}
+	return changed
+}
+
+// watchRemote re-reads the remote provider at remotePollInterval, merging it
+// into i.v and notifying OnChange subscribers for any remoteKeys whose value
+// changed. remoteV is exclusively owned by this goroutine once LoadRemote
+// returns, so it needs no locking of its own. It exits once Close is called.
+func (i *Config) watchRemote() {
+	ticker := time.NewTicker(remotePollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-i.stop:
+			return
+		case <-ticker.C:
+			if err := i.remoteV.ReadRemoteConfig(); err != nil {
+				log.Printf("unable to read remote configuration: %v", err)
+				continue
+			}
+			i.mergeRemote(i.remoteV)
+			for _, key := range i.diffWatched() {
+				i.notify(key)
+			}
+		}
+	}
+}
+
+func (i *Config) notify(key string) {
+	i.mu.Lock()
+	fns := make([]func(string), len(i.onChange))
+	copy(fns, i.onChange)
+	i.mu.Unlock()
+
+	for _, fn := range fns {
+		fn(key)
+	}
+}
+
 // Defaults returns a Config that has just the default values
 // set. It will load neither local nor remote files.
 func Defaults() *Config {
@@ -50,25 +257,60 @@ func Defaults() *Config {
 
 // Set overrides the configuration value. It is used for testing.
 func (i *Config) Set(key string, value interface{}) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
 	i.v.Set(key, value)
 }
 
 // DataSource returns the connection string of the database that
 // stores Config application information.
 func (i *Config) DataSource() string {
+	i.mu.Lock()
+	defer i.mu.Unlock()
 	return i.v.GetString(param.DataSource)
 }
 
 // LoggingLevel returns the application's logging level.
 func (i *Config) LoggingLevel() string {
+	i.mu.Lock()
+	defer i.mu.Unlock()
 	return i.v.GetString(param.LoggingLevel)
 }
 
+// BindAddress returns the address the server listens on.
+func (i *Config) BindAddress() string {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.v.GetString(param.BindAddress)
+}
+
+// Port returns the port the server listens on.
+func (i *Config) Port() int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.v.GetInt(param.Port)
+}
+
+// Viper returns the Viper instance backing this Config, so that callers
+// (such as the cmd package) can bind flags and environment variables onto
+// the same keys that DataSource, LoggingLevel, and friends read from. It
+// bypasses the locking the getters do. A Config returned by LoadRemote with
+// a reachable provider already has its watcher goroutine writing to this
+// instance when LoadRemote returns, so binding onto it races with that
+// goroutine.
+func (i *Config) Viper() *viper.Viper {
+	return i.v
+}
+
 func (i *Config) setDefaults() {
 	const Source = "postgresql://maxroach@localhost:26257/workout?sslmode=disable"
 	const Level = "INFO"
+	const Address = ""
+	const Port = 8080
 	i.v.SetDefault(param.DataSource, Source)
 	i.v.SetDefault(param.LoggingLevel, Level)
+	i.v.SetDefault(param.BindAddress, Address)
+	i.v.SetDefault(param.Port, Port)
 }
 
 func (i *Config) setupEnvVarReader() {
@@ -26,6 +26,11 @@ func TestGetters(t *testing.T) {
 			cfg.LoggingLevel,
 			"INFO",
 		},
+		{
+			"BindAddress",
+			cfg.BindAddress,
+			"",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -35,4 +40,8 @@ func TestGetters(t *testing.T) {
 			}
 		})
 	}
+
+	if actual := cfg.Port(); actual != 8080 {
+		t.Errorf("Port: expected '%d', got '%d'", 8080, actual)
+	}
 }
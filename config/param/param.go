@@ -0,0 +1,18 @@
+// Package param declares the Viper keys used to look up configuration
+// values, so that the key names are shared between Config's getters,
+// defaults, and anything that binds flags or environment variables to them.
+package param
+
+const (
+	// DataSource is the key for the database connection string.
+	DataSource = "data-source"
+
+	// LoggingLevel is the key for the application's logging level.
+	LoggingLevel = "logging-level"
+
+	// BindAddress is the key for the address the server listens on.
+	BindAddress = "bind-address"
+
+	// Port is the key for the port the server listens on.
+	Port = "port"
+)
@@ -0,0 +1,112 @@
+package config_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/brettpechiney/workout-service/config"
+)
+
+// fakeRemoteConfig is an in-process stand-in for viper's remote config
+// factory (normally backed by github.com/spf13/viper/remote talking to
+// etcd/consul), so LoadRemote can be exercised without a real remote store.
+type fakeRemoteConfig struct {
+	value []byte
+	watch chan *viper.RemoteResponse
+}
+
+func (f *fakeRemoteConfig) Get(rp viper.RemoteProvider) (io.Reader, error) {
+	return bytes.NewReader(f.value), nil
+}
+
+func (f *fakeRemoteConfig) Watch(rp viper.RemoteProvider) (io.Reader, error) {
+	return bytes.NewReader(f.value), nil
+}
+
+func (f *fakeRemoteConfig) WatchChannel(rp viper.RemoteProvider) (<-chan *viper.RemoteResponse, chan bool) {
+	quit := make(chan bool)
+	return f.watch, quit
+}
+
+// set updates the value the fake store serves, simulating a rotation that
+// LoadRemote's watcher picks up on its next poll through Get. It also pushes
+// the value down the watch channel, which LoadRemote does not read.
+func (f *fakeRemoteConfig) set(t *testing.T, toml string) {
+	t.Helper()
+	f.value = []byte(toml)
+	f.watch <- &viper.RemoteResponse{Value: f.value}
+}
+
+func TestLoadRemote(t *testing.T) {
+	fake := &fakeRemoteConfig{
+		value: []byte(`data-source = "postgresql://remote@localhost:26257/workout?sslmode=disable"`),
+		watch: make(chan *viper.RemoteResponse, 1),
+	}
+	viper.RemoteConfig = fake
+
+	cfg, err := config.LoadRemote(nil, config.RemoteProvider{
+		Name:     "consul",
+		Endpoint: "http://127.0.0.1:8500",
+		Path:     "/config/workout-service",
+	})
+	if err != nil {
+		t.Fatalf("LoadRemote: %v", err)
+	}
+	t.Cleanup(cfg.Close)
+	if !cfg.Remote() {
+		t.Fatalf("Remote: expected true once the provider is reachable")
+	}
+
+	const want = "postgresql://remote@localhost:26257/workout?sslmode=disable"
+	if actual := cfg.DataSource(); actual != want {
+		t.Errorf("DataSource: expected %q, got %q", want, actual)
+	}
+
+	changed := make(chan string, 1)
+	cfg.OnChange(func(key string) { changed <- key })
+
+	fake.set(t, `data-source = "postgresql://rotated@localhost:26257/workout?sslmode=disable"`)
+
+	select {
+	case key := <-changed:
+		if key != "data-source" {
+			t.Errorf("OnChange: expected key 'data-source', got %q", key)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("OnChange: timed out waiting for the rotation to be picked up")
+	}
+}
+
+func TestLoadRemoteFallsBackWhenUnreachable(t *testing.T) {
+	viper.RemoteConfig = unreachableRemoteConfig{}
+
+	cfg, err := config.LoadRemote(nil, config.RemoteProvider{
+		Name:     "etcd",
+		Endpoint: "http://127.0.0.1:4001",
+		Path:     "/config/workout-service",
+	})
+	if err != nil {
+		t.Fatalf("LoadRemote: %v", err)
+	}
+	if cfg.Remote() {
+		t.Fatalf("Remote: expected false when the provider is unreachable")
+	}
+}
+
+type unreachableRemoteConfig struct{}
+
+func (unreachableRemoteConfig) Get(rp viper.RemoteProvider) (io.Reader, error) {
+	return nil, io.ErrClosedPipe
+}
+
+func (unreachableRemoteConfig) Watch(rp viper.RemoteProvider) (io.Reader, error) {
+	return nil, io.ErrClosedPipe
+}
+
+func (unreachableRemoteConfig) WatchChannel(rp viper.RemoteProvider) (<-chan *viper.RemoteResponse, chan bool) {
+	return nil, nil
+}
@@ -0,0 +1,103 @@
+// Package dbsetup manages a throwaway CockroachDB instance for local
+// development and integration tests. It replaces the old StartRoachContainer
+// mage target, which only shelled out to docker-compose and mage from a
+// developer workstation, with a library that tests can call directly.
+package dbsetup
+
+import (
+	"context"
+	"database/sql"
+	"net/url"
+	"os/exec"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/cockroachdb"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	_ "github.com/lib/pq"
+	"github.com/pkg/errors"
+
+	"github.com/brettpechiney/workout-service/config"
+)
+
+const (
+	composeService       = "cockroach"
+	defaultMigrationsDir = "./migrations"
+	pollInterval         = 500 * time.Millisecond
+)
+
+// Environment holds everything needed to bring up a CockroachDB instance,
+// wait for it to accept connections, run its migrations, and tear it back
+// down: the Docker Compose service to drive and the connection parameters
+// pulled from config.Config.DataSource().
+type Environment struct {
+	cfg           *config.Config
+	migrationsDir string
+}
+
+// New returns an Environment that targets the data source configured in cfg.
+func New(cfg *config.Config) *Environment {
+	return &Environment{cfg: cfg, migrationsDir: defaultMigrationsDir}
+}
+
+// Start brings up the CockroachDB docker-compose service in the background.
+func (e *Environment) Start(ctx context.Context) error {
+	cmd := exec.CommandContext(ctx, "docker-compose", "up", "-d", composeService)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "starting %s: %s", composeService, out)
+	}
+	return nil
+}
+
+// WaitReady blocks until the database accepts connections or ctx is done.
+func (e *Environment) WaitReady(ctx context.Context) error {
+	db, err := sql.Open("postgres", e.cfg.DataSource())
+	if err != nil {
+		return errors.Wrap(err, "opening connection to cockroach")
+	}
+	defer db.Close()
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		if err := db.PingContext(ctx); err == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return errors.Wrap(ctx.Err(), "waiting for cockroach to become ready")
+		case <-ticker.C:
+		}
+	}
+}
+
+// Migrate runs all pending migrations from migrationsDir against the
+// configured data source.
+func (e *Environment) Migrate(ctx context.Context) error {
+	// The cockroachdb migrate driver registers the cockroachdb:// scheme, not
+	// the postgres(ql):// one the data source is written with.
+	dataSource := e.cfg.DataSource()
+	if u, err := url.Parse(dataSource); err == nil && (u.Scheme == "postgres" || u.Scheme == "postgresql") {
+		u.Scheme = "cockroachdb"
+		dataSource = u.String()
+	}
+
+	m, err := migrate.New("file://"+e.migrationsDir, dataSource)
+	if err != nil {
+		return errors.Wrap(err, "building migration driver")
+	}
+	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
+		return errors.Wrap(err, "running migrations")
+	}
+	return nil
+}
+
+// Stop tears down the CockroachDB docker-compose service along with its
+// volumes, so the next Start begins from a clean database.
+func (e *Environment) Stop(ctx context.Context) error {
+	cmd := exec.CommandContext(ctx, "docker-compose", "down", "-v")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "stopping %s: %s", composeService, out)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+// +build integration
+
+package dbsetup_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/brettpechiney/workout-service/config"
+	"github.com/brettpechiney/workout-service/dbsetup"
+)
+
+// TestEnvironmentLifecycle spins up a real CockroachDB container, runs
+// migrations against it, and tears it down again. It requires Docker and is
+// gated behind the integration build tag so `go test ./...` stays fast and
+// hermetic by default.
+func TestEnvironmentLifecycle(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	env := dbsetup.New(config.Defaults())
+	if err := env.Start(ctx); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer func() {
+		if err := env.Stop(context.Background()); err != nil {
+			t.Errorf("Stop: %v", err)
+		}
+	}()
+
+	if err := env.WaitReady(ctx); err != nil {
+		t.Fatalf("WaitReady: %v", err)
+	}
+	if err := env.Migrate(ctx); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+}
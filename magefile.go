@@ -4,6 +4,7 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -16,11 +17,15 @@ import (
 
 	"github.com/magefile/mage/mg"
 	"github.com/magefile/mage/sh"
+
+	"github.com/brettpechiney/workout-service/config"
+	"github.com/brettpechiney/workout-service/dbsetup"
 )
 
 const (
 	goVersion   = "1.11"
 	packageName = "github.com/brettpechiney/workout-service"
+	versionPkg  = packageName + "/version"
 )
 
 var (
@@ -30,26 +35,27 @@ var (
 	releaseTag = regexp.MustCompile(`^v+[0-9]+\.[0-9]+\.[0-9]+$`)
 )
 
-// StartRoachContainer starts the CockroachDB container and kicks off it's
-// migration scripts.
+// StartRoachContainer starts the CockroachDB container and kicks off its
+// migration scripts. It is a thin shim over the dbsetup package, which does
+// the actual work so that tests can drive the same flow in-process.
 func StartRoachContainer() error {
-	const MsgPrefix = "in StartRoachContainer"
-	stopped := make(chan struct{})
-	errchan := make(chan error)
-	go func() {
-		defer close(stopped)
-		if err := sh.Run("cmd", "/C", "start", "docker-compose", "up", "cockroach"); err != nil {
-			errchan <- fmt.Errorf("%s: %v", MsgPrefix, err)
-		}
-	}()
-	for {
-		select {
-		case err := <-errchan:
-			return err
-		case <-stopped:
-			return sh.Run("mage", "-d", "./migrations", "Migrate")
-		}
+	cfg, err := config.Load([]string{"."})
+	if err != nil {
+		return fmt.Errorf("in StartRoachContainer: %v", err)
 	}
+
+	ctx := context.Background()
+	env := dbsetup.New(cfg)
+	if err := env.Start(ctx); err != nil {
+		return fmt.Errorf("in StartRoachContainer: %v", err)
+	}
+	if err := env.WaitReady(ctx); err != nil {
+		return fmt.Errorf("in StartRoachContainer: %v", err)
+	}
+	if err := env.Migrate(ctx); err != nil {
+		return fmt.Errorf("in StartRoachContainer: %v", err)
+	}
+	return nil
 }
 
 // Install runs go install and generates version information in the binary.
@@ -212,9 +218,9 @@ func flags() string {
 	if tag == "" {
 		tag = "dev"
 	}
-	ts := fmt.Sprintf("%s.timestamp=%s", packageName, timestamp)
-	ghash := fmt.Sprintf("%s.commitHash=%s", packageName, hash)
-	rtag := fmt.Sprintf("%s.gitTag=%s", packageName, tag)
+	ts := fmt.Sprintf("%s.BuildTime=%s", versionPkg, timestamp)
+	ghash := fmt.Sprintf("%s.Commit=%s", versionPkg, hash)
+	rtag := fmt.Sprintf("%s.Version=%s", versionPkg, tag)
 	return fmt.Sprintf(`-X %s -X %s -X %s `, ts, ghash, rtag)
 }
 
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/brettpechiney/workout-service/cmd"
+	"github.com/brettpechiney/workout-service/config"
+	"github.com/brettpechiney/workout-service/version"
+)
+
+const defaultRoot = "."
+
+func main() {
+	log.Printf("starting workout-service: %s", version.Get())
+
+	cfg, err := config.Load([]string{defaultRoot})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	root, err := cmd.PrepareRootCmd(cfg, "WORKOUT", defaultRoot)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if err := root.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
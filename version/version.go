@@ -0,0 +1,121 @@
+// Package version exposes the build metadata that the magefile injects via
+// -ldflags -X, plus an API for checking that metadata against the newest
+// GitHub release so a running binary can report whether it is out of date.
+package version
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/pkg/errors"
+)
+
+// These are overwritten at build time via -ldflags, e.g.
+// -X github.com/brettpechiney/workout-service/version.Version=v1.2.3.
+var (
+	// Version is the git tag the binary was built from.
+	Version string
+
+	// Commit is the short git commit hash the binary was built from.
+	Commit string
+
+	// BuildTime is the RFC3339 timestamp of the build.
+	BuildTime string
+)
+
+// releaseTag matches the same vX.Y.Z format the magefile uses to validate
+// release tags, so CheckLatest only considers tags this project actually cuts.
+var releaseTag = regexp.MustCompile(`^v+[0-9]+\.[0-9]+\.[0-9]+$`)
+
+// githubAPIBase is overridden in tests so CheckLatest can be exercised
+// against a local server instead of the real GitHub API.
+var githubAPIBase = "https://api.github.com"
+
+// Info describes the build metadata compiled into the running binary.
+type Info struct {
+	Version   string
+	Commit    string
+	BuildTime string
+	SemVer    *semver.Version
+}
+
+// String renders Info the way the version subcommand and startup log line
+// present it.
+func (i Info) String() string {
+	return fmt.Sprintf("version=%s commit=%s built=%s", i.Version, i.Commit, i.BuildTime)
+}
+
+// Get returns the build metadata compiled into the running binary, parsing
+// Version as a SemVer when semver.NewVersion accepts it. SemVer is nil when
+// it does not; Version is not checked against the release tag format.
+func Get() Info {
+	info := Info{Version: Version, Commit: Commit, BuildTime: BuildTime}
+	if v, err := semver.NewVersion(Version); err == nil {
+		info.SemVer = v
+	}
+	return info
+}
+
+// CheckLatest queries the GitHub releases API for repo's newest vX.Y.Z tag
+// and reports whether the compiled-in Version is behind it.
+func CheckLatest(ctx context.Context, repo string) (latest string, isOutdated bool, err error) {
+	current, err := semver.NewVersion(Version)
+	if err != nil {
+		return "", false, errors.Wrapf(err, "parsing compiled-in version %q", Version)
+	}
+
+	releases, err := fetchReleases(ctx, repo)
+	if err != nil {
+		return "", false, err
+	}
+
+	var versions []*semver.Version
+	for _, r := range releases {
+		if !releaseTag.MatchString(r.TagName) {
+			continue
+		}
+		if v, err := semver.NewVersion(r.TagName); err == nil {
+			versions = append(versions, v)
+		}
+	}
+	if len(versions) == 0 {
+		return "", false, errors.Errorf("no release tags matching %s found for %s", releaseTag, repo)
+	}
+	sort.Sort(semver.Collection(versions))
+	newest := versions[len(versions)-1]
+
+	return "v" + newest.String(), newest.GreaterThan(current), nil
+}
+
+type release struct {
+	TagName string `json:"tag_name"`
+}
+
+func fetchReleases(ctx context.Context, repo string) ([]release, error) {
+	url := fmt.Sprintf("%s/repos/%s/releases", githubAPIBase, repo)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "building GitHub releases request")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "querying GitHub releases")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("GitHub releases API returned %s", resp.Status)
+	}
+
+	var releases []release
+	if err := json.NewDecoder(resp.Body).Decode(&releases); err != nil {
+		return nil, errors.Wrap(err, "decoding GitHub releases response")
+	}
+	return releases, nil
+}
@@ -0,0 +1,51 @@
+package version
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGet(t *testing.T) {
+	defer resetBuildVars()
+	Version, Commit, BuildTime = "v1.2.3", "abc1234", "2020-01-01T00:00:00Z"
+
+	info := Get()
+	if info.SemVer == nil {
+		t.Fatalf("Get: expected SemVer to be parsed from %q", Version)
+	}
+	if info.SemVer.String() != "1.2.3" {
+		t.Errorf("SemVer: expected '1.2.3', got %q", info.SemVer.String())
+	}
+}
+
+func TestCheckLatest(t *testing.T) {
+	defer resetBuildVars()
+	Version = "v1.0.0"
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"tag_name":"v1.2.0"},{"tag_name":"v1.1.0"},{"tag_name":"not-a-release"}]`))
+	}))
+	defer srv.Close()
+
+	oldBase := githubAPIBase
+	githubAPIBase = srv.URL
+	defer func() { githubAPIBase = oldBase }()
+
+	latest, isOutdated, err := CheckLatest(context.Background(), "brettpechiney/workout-service")
+	if err != nil {
+		t.Fatalf("CheckLatest: %v", err)
+	}
+	if latest != "v1.2.0" {
+		t.Errorf("latest: expected 'v1.2.0', got %q", latest)
+	}
+	if !isOutdated {
+		t.Errorf("isOutdated: expected true")
+	}
+}
+
+func resetBuildVars() {
+	Version, Commit, BuildTime = "", "", ""
+}